@@ -0,0 +1,121 @@
+// Package admin provides a built-in lazyservice.Service exposing health,
+// readiness, Prometheus metrics, pprof, and expvar endpoints on their own
+// listener, so hosted apps don't have to hand-roll this boilerplate in a
+// serviceFunc.
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golazy.dev/lazyservice"
+)
+
+// New creates an admin Service listening on addr. Add it to a Manager
+// with AddService like any other service.
+func New(addr string) lazyservice.Service {
+	return &admin{addr: addr}
+}
+
+type admin struct {
+	addr   string
+	server *http.Server
+}
+
+func (a *admin) Desc() lazyservice.ServiceDescription {
+	return adminDesc{}
+}
+
+type adminDesc struct{}
+
+func (adminDesc) Name() string { return "admin" }
+
+func (a *admin) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", a.healthz(ctx))
+	mux.HandleFunc("/readyz", a.readyz(ctx))
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	a.server = &http.Server{Addr: a.addr, Handler: mux}
+	return nil
+}
+
+func (a *admin) Run(ctx context.Context) error {
+	// The manager calls Stop only after every Run has returned, so Run
+	// shuts the server down itself once ctx is done.
+	go func() {
+		<-ctx.Done()
+		a.server.Shutdown(context.Background())
+	}()
+	err := a.server.ListenAndServe()
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+func (a *admin) Stop(ctx context.Context) error {
+	return a.server.Shutdown(ctx)
+}
+
+// healthz reports liveness: the process is alive as long as the
+// manager's root context (rootCtx) hasn't been canceled.
+func (a *admin) healthz(rootCtx context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := rootCtx.Err(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}
+}
+
+// readyz aggregates HealthCheck and Readier results across every service
+// registered with the manager found on rootCtx.
+func (a *admin) readyz(rootCtx context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		mgr := lazyservice.ManagerFromContext(rootCtx)
+
+		status := map[string]string{}
+		ready := true
+		if mgr != nil {
+			for _, s := range mgr.Services() {
+				name := s.Desc().Name()
+				if hc, ok := s.(lazyservice.HealthChecker); ok {
+					if err := hc.HealthCheck(rootCtx); err != nil {
+						status[name] = err.Error()
+						ready = false
+						continue
+					}
+				}
+				if rdr, ok := s.(lazyservice.Readier); ok {
+					select {
+					case <-rdr.Ready():
+						status[name] = "ready"
+					default:
+						status[name] = "not ready"
+						ready = false
+					}
+					continue
+				}
+				status[name] = "ok"
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if !ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(status)
+	}
+}
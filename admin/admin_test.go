@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golazy.dev/lazyservice"
+)
+
+func TestDescName(t *testing.T) {
+	a := New(":0")
+	if a.Desc().Name() != "admin" {
+		t.Error("name is:", a.Desc().Name())
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	a := &admin{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := httptest.NewRecorder()
+	a.healthz(ctx)(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if w.Code != http.StatusOK {
+		t.Error("status is:", w.Code)
+	}
+
+	cancel()
+	w = httptest.NewRecorder()
+	a.healthz(ctx)(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Error("status is:", w.Code)
+	}
+}
+
+// fakeService is a ready, healthy lazyservice.Service used to drive a real
+// Manager.Run for TestReadyzThroughRunningManager.
+type fakeService struct {
+	started chan struct{}
+}
+
+func (f *fakeService) Desc() lazyservice.ServiceDescription { return fakeDesc{} }
+func (f *fakeService) Start(ctx context.Context) error      { close(f.started); return nil }
+func (f *fakeService) Run(ctx context.Context) error        { <-ctx.Done(); return ctx.Err() }
+
+type fakeDesc struct{}
+
+func (fakeDesc) Name() string { return "fake" }
+
+// TestReadyzThroughRunningManager exercises readyz against the Manager
+// that a real Run installs on the app context with lazycontext.Set: a
+// root context that never went through a real Run wouldn't catch a
+// regression here.
+func TestReadyzThroughRunningManager(t *testing.T) {
+	svc := &fakeService{started: make(chan struct{})}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	mgr := lazyservice.NewWithContext(ctx)
+	mgr.AddService(svc)
+
+	done := make(chan error, 1)
+	go func() { done <- mgr.Run() }()
+
+	select {
+	case <-svc.started:
+	case <-time.After(time.Second):
+		t.Fatal("service never started")
+	}
+
+	a := &admin{}
+	w := httptest.NewRecorder()
+	a.readyz(mgr)(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusOK {
+		t.Error("status is:", w.Code)
+	}
+
+	var status map[string]string
+	if err := json.NewDecoder(w.Body).Decode(&status); err != nil {
+		t.Fatal(err)
+	}
+	if status["fake"] != "ok" {
+		t.Errorf("status for fake service: %v", status)
+	}
+
+	<-done
+}
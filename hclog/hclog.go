@@ -0,0 +1,28 @@
+// Package hclog adapts github.com/hashicorp/go-hclog to lazyservice.Logger,
+// for apps that already standardize on hclog (as nomad and consul do).
+package hclog
+
+import (
+	hclog "github.com/hashicorp/go-hclog"
+	"golazy.dev/lazyservice"
+)
+
+// New adapts an hclog.Logger to lazyservice.Logger. Set it with
+// lazyservice.SetLogger before calling Manager.Run to use it as the app's
+// logger.
+func New(l hclog.Logger) lazyservice.Logger {
+	return adapter{l: l}
+}
+
+type adapter struct {
+	l hclog.Logger
+}
+
+func (a adapter) Debug(msg string, kv ...any) { a.l.Debug(msg, kv...) }
+func (a adapter) Info(msg string, kv ...any)  { a.l.Info(msg, kv...) }
+func (a adapter) Warn(msg string, kv ...any)  { a.l.Warn(msg, kv...) }
+func (a adapter) Error(msg string, kv ...any) { a.l.Error(msg, kv...) }
+
+func (a adapter) With(kv ...any) lazyservice.Logger {
+	return adapter{l: a.l.With(kv...)}
+}
@@ -0,0 +1,55 @@
+package hclog
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	hc "github.com/hashicorp/go-hclog"
+)
+
+func newTestLogger(buf *bytes.Buffer) hc.Logger {
+	return hc.New(&hc.LoggerOptions{
+		Output:     buf,
+		Level:      hc.Debug,
+		JSONFormat: true,
+	})
+}
+
+func TestAdapterLevelsAndFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(newTestLogger(&buf))
+	l.Info("listening", "port", 8080)
+
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatal(err)
+	}
+	if line["@message"] != "listening" {
+		t.Errorf("message is: %v", line["@message"])
+	}
+	if line["@level"] != "info" {
+		t.Errorf("level is: %v", line["@level"])
+	}
+	if line["port"] != float64(8080) {
+		t.Errorf("port is: %v", line["port"])
+	}
+}
+
+func TestAdapterWithAddsFieldsToSubsequentCalls(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(newTestLogger(&buf))
+	l = l.With("service", "http")
+	l.Warn("slow request")
+
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatal(err)
+	}
+	if line["service"] != "http" {
+		t.Errorf("service is: %v", line["service"])
+	}
+	if line["@message"] != "slow request" {
+		t.Errorf("message is: %v", line["@message"])
+	}
+}
@@ -8,12 +8,10 @@ package lazyservice
 
 import (
 	"context"
-	"log/slog"
-
-	"golazy.dev/lazycontext"
+	"sync"
 )
 
-func serviceFunc(name string, f func(context.Context, *slog.Logger) error) Service {
+func serviceFunc(name string, f func(context.Context, Logger) error) Service {
 	return &srvFn{
 		name: name,
 		f:    f,
@@ -22,16 +20,11 @@ func serviceFunc(name string, f func(context.Context, *slog.Logger) error) Servi
 
 type srvFn struct {
 	name string
-	f    func(context.Context, *slog.Logger) error
+	f    func(context.Context, Logger) error
 }
 
 func (f *srvFn) Run(ctx context.Context) error {
-	l := lazycontext.Get[*slog.Logger](ctx)
-	if l == nil {
-		l = slog.Default()
-	}
-
-	return f.f(ctx, l)
+	return f.f(ctx, GetLogger(ctx))
 }
 
 func (f *srvFn) Desc() ServiceDescription {
@@ -55,3 +48,74 @@ type Service interface {
 	Desc() ServiceDescription
 	Run(context.Context) error
 }
+
+// Starter is an optional interface a Service can implement to run
+// initialization logic before the manager calls its Run. Services are
+// started in dependency order (see DependsOn), so a service's Start may
+// run while services launched before it are already running.
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// DependsOn is an optional interface a ServiceDescription can implement
+// to declare the names of other services that must become ready before
+// this service is started. manager.Run topologically sorts services by
+// Requires and reports an error if it finds a cycle or an unknown name.
+type DependsOn interface {
+	Requires() []string
+}
+
+// Readier is an optional interface a Service can implement to signal its
+// own readiness to services that depend on it. The returned channel is
+// closed once the service is ready. A service that doesn't implement
+// Readier becomes ready when it calls MarkReady from within Start or Run.
+// A service with a Starter that never calls MarkReady becomes ready as
+// soon as Start returns; one with no Starter that never calls MarkReady
+// becomes ready once its first Run call returns, so a dependent is never
+// stuck on a service that was never going to signal readiness, without
+// that fallback racing ahead of an explicit MarkReady call made partway
+// through Run.
+type Readier interface {
+	Ready() <-chan struct{}
+}
+
+type readyStateKey struct{}
+
+// readyState is the manager-managed readiness channel injected into a
+// service's context so it can call MarkReady once initialized.
+type readyState struct {
+	once sync.Once
+	ch   chan struct{}
+}
+
+func newReadyState() *readyState {
+	return &readyState{ch: make(chan struct{})}
+}
+
+func (r *readyState) markReady() {
+	r.once.Do(func() { close(r.ch) })
+}
+
+// MarkReady signals that the service bound to ctx has finished
+// initializing, releasing any services waiting on it as a dependency.
+// It is a no-op if ctx wasn't passed to the service by the manager, or if
+// the service has already been marked ready.
+func MarkReady(ctx context.Context) {
+	if rs, ok := ctx.Value(readyStateKey{}).(*readyState); ok {
+		rs.markReady()
+	}
+}
+
+// Stopper is an optional interface a Service can implement to run
+// cleanup logic once the app is shutting down. Services are stopped in
+// the reverse of the order they were started (dependency order, see
+// DependsOn), bounded by the manager's stop timeout.
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+// HealthChecker is an optional interface a Service can implement to
+// report its health independently of whether Run has returned.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
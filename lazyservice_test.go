@@ -2,9 +2,12 @@ package lazyservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log/slog"
 	"net/http"
+	"os"
+	"sync"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -23,7 +26,7 @@ func TestAppNameAndVersion(t *testing.T) {
 
 func TestServiceFunc(t *testing.T) {
 
-	service := func(ctx context.Context, l *slog.Logger) error {
+	service := func(ctx context.Context, l Logger) error {
 		l.Info("hi")
 		return fmt.Errorf("hi")
 	}
@@ -47,7 +50,7 @@ func TestLazyApp(t *testing.T) {
 	defer cancel()
 	app := NewWithContext(ctx)
 	app.AddValue("key", "value")
-	app.AddService(serviceFunc("http", func(ctx context.Context, l *slog.Logger) error {
+	app.AddService(serviceFunc("http", func(ctx context.Context, l Logger) error {
 
 		s := &http.Server{
 			Addr: ":8083",
@@ -57,17 +60,17 @@ func TestLazyApp(t *testing.T) {
 		go func() {
 			defer close(idleConnsClosed)
 			<-ctx.Done()
-			l.InfoContext(app, "shutting down")
+			l.Info("shutting down")
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 			defer cancel()
 			err := s.Shutdown(ctx)
 			if err == nil || err == context.Canceled || err == context.DeadlineExceeded {
 				return
 			}
-			l.ErrorContext(app, err.Error(), "err", err)
+			l.Error(err.Error(), "err", err)
 		}()
 
-		l.InfoContext(app, "listening on 8083")
+		l.Info("listening on 8083")
 		err := s.ListenAndServe()
 		if err != http.ErrServerClosed {
 			return err
@@ -83,3 +86,402 @@ func TestLazyApp(t *testing.T) {
 	}
 
 }
+
+// depService is a Service whose ServiceDescription optionally implements
+// DependsOn, for exercising topoSort and Run's dependency gating.
+type depService struct {
+	name string
+	deps []string
+	run  func(ctx context.Context) error
+}
+
+func (d *depService) Desc() ServiceDescription { return depDesc{name: d.name, deps: d.deps} }
+func (d *depService) Run(ctx context.Context) error {
+	if d.run != nil {
+		return d.run(ctx)
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+type depDesc struct {
+	name string
+	deps []string
+}
+
+func (d depDesc) Name() string       { return d.name }
+func (d depDesc) Requires() []string { return d.deps }
+
+// starterDepService is a depService variant that also implements Starter,
+// for exercising a dependency on a service that becomes ready via the
+// "as soon as its Start returns" fallback rather than an explicit
+// MarkReady call.
+type starterDepService struct {
+	name  string
+	deps  []string
+	start func(ctx context.Context) error
+}
+
+func (d *starterDepService) Desc() ServiceDescription        { return depDesc{name: d.name, deps: d.deps} }
+func (d *starterDepService) Start(ctx context.Context) error { return d.start(ctx) }
+func (d *starterDepService) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestTopoSortCycle(t *testing.T) {
+	services := []Service{
+		&depService{name: "a", deps: []string{"b"}},
+		&depService{name: "b", deps: []string{"a"}},
+	}
+	if _, err := topoSort(services); err == nil {
+		t.Fatal("expected a cycle error, got nil")
+	}
+}
+
+func TestTopoSortUnknownDependency(t *testing.T) {
+	services := []Service{
+		&depService{name: "a", deps: []string{"missing"}},
+	}
+	if _, err := topoSort(services); err == nil {
+		t.Fatal("expected an unknown-dependency error, got nil")
+	}
+}
+
+// hookService is a Service with a settable Stop, for exercising
+// AddBeforeStart/AddAfterStop ordering around a real Run.
+type hookService struct {
+	name   string
+	run    func(ctx context.Context) error
+	onStop func(ctx context.Context) error
+}
+
+func (h *hookService) Desc() ServiceDescription { return serviceFuncDesc{name: h.name} }
+func (h *hookService) Run(ctx context.Context) error {
+	if h.run != nil {
+		return h.run(ctx)
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+func (h *hookService) Stop(ctx context.Context) error { return h.onStop(ctx) }
+
+// startFailService is a Starter whose Start always fails, for exercising
+// Run's shutdown path when startup is aborted partway through.
+type startFailService struct {
+	name string
+	err  error
+}
+
+func (s *startFailService) Desc() ServiceDescription        { return serviceFuncDesc{name: s.name} }
+func (s *startFailService) Start(ctx context.Context) error { return s.err }
+func (s *startFailService) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestLifecycleHookOrdering(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	record := func(s string) {
+		mu.Lock()
+		order = append(order, s)
+		mu.Unlock()
+	}
+
+	svc := &hookService{
+		name:   "svc",
+		onStop: func(ctx context.Context) error { record("service-stop"); return nil },
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	mgr := NewWithContext(ctx)
+	mgr.AddBeforeStart(func(ctx context.Context) error { record("before1"); return nil })
+	mgr.AddBeforeStart(func(ctx context.Context) error { record("before2"); return nil })
+	mgr.AddAfterStop(func(ctx context.Context) error { record("after1"); return nil })
+	mgr.AddAfterStop(func(ctx context.Context) error { record("after2"); return nil })
+	mgr.AddService(svc)
+
+	if err := mgr.Run(); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal(err)
+	}
+
+	want := []string{"before1", "before2", "service-stop", "after1", "after2"}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i, s := range want {
+		if order[i] != s {
+			t.Errorf("order[%d] = %q, want %q (full: %v)", i, order[i], s, order)
+		}
+	}
+}
+
+// TestReloadHook sends a real SIGHUP to the test process and checks it
+// reaches OnReload, guarding the claim in NewWithSignals's doc comment
+// that SIGHUP always triggers reload hooks regardless of the trapped
+// signal set.
+func TestReloadHook(t *testing.T) {
+	reloaded := make(chan struct{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	mgr := NewWithContext(ctx)
+	mgr.OnReload(func(ctx context.Context) error {
+		close(reloaded)
+		return nil
+	})
+	mgr.AddService(&depService{name: "svc"})
+
+	done := make(chan error, 1)
+	go func() { done <- mgr.Run() }()
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-reloaded:
+	case <-time.After(time.Second):
+		t.Fatal("reload hook never ran")
+	}
+
+	cancel()
+	<-done
+}
+
+func TestAddServiceWithPolicyNilBackoff(t *testing.T) {
+	svc := &depService{
+		name: "flaky",
+		run: func(ctx context.Context) error {
+			return fmt.Errorf("boom")
+		},
+	}
+
+	mgr := NewWithContext(context.Background())
+	mgr.AddServiceWithPolicy(svc, RestartPolicy{MaxRestarts: 3})
+
+	err := mgr.Run()
+	if err == nil || err.Error() != "boom" {
+		t.Fatalf("expected the service's error with no Backoff set, got: %v", err)
+	}
+}
+
+func TestDependsOnOrdering(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	record := func(s string) {
+		mu.Lock()
+		order = append(order, s)
+		mu.Unlock()
+	}
+
+	first := &starterDepService{
+		name: "first",
+		start: func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			record("first-ready")
+			return nil
+		},
+	}
+	second := &depService{
+		name: "second",
+		deps: []string{"first"},
+		run: func(ctx context.Context) error {
+			record("second-started")
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	mgr := NewWithContext(ctx)
+	mgr.AddService(second)
+	mgr.AddService(first)
+
+	if err := mgr.Run(); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != 2 || order[0] != "first-ready" || order[1] != "second-started" {
+		t.Errorf("expected first to become ready before second started, got %v", order)
+	}
+}
+
+// TestDependsOnOrderingReadyOnceRunReturns exercises a dependency on a
+// service with no Starter that never calls MarkReady: per Readier's doc
+// comment, such a service becomes ready once its first Run call returns,
+// so the dependent is never stuck waiting on a service that was never
+// going to signal its own readiness.
+func TestDependsOnOrderingReadyOnceRunReturns(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	record := func(s string) {
+		mu.Lock()
+		order = append(order, s)
+		mu.Unlock()
+	}
+
+	first := &depService{
+		name: "first",
+		run: func(ctx context.Context) error {
+			record("first-ran")
+			return nil
+		},
+	}
+	second := &depService{
+		name: "second",
+		deps: []string{"first"},
+		run: func(ctx context.Context) error {
+			record("second-started")
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	mgr := NewWithContext(ctx)
+	mgr.AddService(second)
+	mgr.AddService(first)
+
+	if err := mgr.Run(); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != 2 || order[0] != "first-ran" || order[1] != "second-started" {
+		t.Errorf("expected second to start only after first's Run returned, got %v", order)
+	}
+}
+
+// TestDependsOnOrderingExplicitMarkReadyFromRun exercises a service with
+// no Starter that signals its own readiness with an explicit
+// MarkReady(ctx) call partway through a Run that otherwise keeps running:
+// the dependent must wait for that call rather than be released by the
+// no-Starter fallback, which only applies once Run returns.
+func TestDependsOnOrderingExplicitMarkReadyFromRun(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	record := func(s string) {
+		mu.Lock()
+		order = append(order, s)
+		mu.Unlock()
+	}
+
+	first := &depService{
+		name: "first",
+		run: func(ctx context.Context) error {
+			time.Sleep(50 * time.Millisecond)
+			record("first-ready")
+			MarkReady(ctx)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+	second := &depService{
+		name: "second",
+		deps: []string{"first"},
+		run: func(ctx context.Context) error {
+			record("second-started")
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	mgr := NewWithContext(ctx)
+	mgr.AddService(second)
+	mgr.AddService(first)
+
+	done := make(chan error, 1)
+	go func() { done <- mgr.Run() }()
+
+	select {
+	case <-time.After(20 * time.Millisecond):
+	case <-done:
+		t.Fatal("Run returned before the context timeout")
+	}
+
+	mu.Lock()
+	tooEarly := len(order) != 0
+	mu.Unlock()
+	if tooEarly {
+		t.Fatalf("second started before first called MarkReady: %v", order)
+	}
+
+	deadline := time.After(time.Second)
+	for {
+		mu.Lock()
+		n := len(order)
+		mu.Unlock()
+		if n >= 2 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("second never started after first's explicit MarkReady call")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != 2 || order[0] != "first-ready" || order[1] != "second-started" {
+		t.Errorf("expected second to start only after first's explicit MarkReady call, got %v", order)
+	}
+}
+
+// TestStartFailureStopsAlreadyStartedServices exercises that a Start
+// failure midway through Run's startup loop still triggers the ordered
+// shutdown: services already started are stopped in reverse order and
+// the after-stop hooks still run, instead of Run returning straight out
+// of the loop.
+func TestStartFailureStopsAlreadyStartedServices(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	record := func(s string) {
+		mu.Lock()
+		order = append(order, s)
+		mu.Unlock()
+	}
+
+	first := &hookService{
+		name:   "first",
+		onStop: func(ctx context.Context) error { record("first-stop"); return nil },
+	}
+	second := &startFailService{name: "second", err: fmt.Errorf("boom")}
+
+	mgr := NewWithContext(context.Background())
+	mgr.AddAfterStop(func(ctx context.Context) error { record("after-stop"); return nil })
+	mgr.AddService(first)
+	mgr.AddService(second)
+
+	err := mgr.Run()
+	if err == nil || err.Error() != "boom" {
+		t.Fatalf("expected the Start failure to propagate, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"first-stop", "after-stop"}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i, s := range want {
+		if order[i] != s {
+			t.Errorf("order[%d] = %q, want %q (full: %v)", i, order[i], s, order)
+		}
+	}
+}
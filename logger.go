@@ -0,0 +1,65 @@
+package lazyservice
+
+import (
+	"context"
+	"log/slog"
+
+	"golazy.dev/lazycontext"
+)
+
+// Logger is the structured logging interface manager.Run and hosted
+// services use. Implementations adapt a specific logging library; see
+// NewSlogLogger for the default, and the lazyservice/hclog and
+// lazyservice/zerolog subpackages for the others. Set a custom one with
+// SetLogger before calling Run.
+type Logger interface {
+	Debug(msg string, kv ...any)
+	Info(msg string, kv ...any)
+	Warn(msg string, kv ...any)
+	Error(msg string, kv ...any)
+	With(kv ...any) Logger
+}
+
+// NewSlogLogger adapts a *slog.Logger to Logger. It is the default used
+// by Run when no Logger has been set through SetLogger.
+func NewSlogLogger(l *slog.Logger) Logger {
+	return slogLogger{l: l}
+}
+
+// loggerBox holds a Logger for storage through lazycontext.Set/Get.
+// lazycontext keys values by the reflected type of T, which is nil for an
+// interface type, so Set[Logger] panics with "nil key"; boxing it in a
+// concrete struct keys it by loggerBox instead.
+type loggerBox struct{ l Logger }
+
+// SetLogger overrides the Logger Run installs as the app's default and
+// injects into every service, instead of the slog-based default. Call it
+// before Run.
+func SetLogger(app lazycontext.AppContext, l Logger) {
+	lazycontext.Set(app, loggerBox{l: l})
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+func (s slogLogger) Debug(msg string, kv ...any) { s.l.Debug(msg, kv...) }
+func (s slogLogger) Info(msg string, kv ...any)  { s.l.Info(msg, kv...) }
+func (s slogLogger) Warn(msg string, kv ...any)  { s.l.Warn(msg, kv...) }
+func (s slogLogger) Error(msg string, kv ...any) { s.l.Error(msg, kv...) }
+
+func (s slogLogger) With(kv ...any) Logger {
+	return slogLogger{l: s.l.With(kv...)}
+}
+
+type loggerKey struct{}
+
+// GetLogger returns the Logger the manager injected into ctx, scoped to
+// the calling service, falling back to the default slog logger if ctx
+// wasn't handed to a service by a Manager.
+func GetLogger(ctx context.Context) Logger {
+	if l, ok := ctx.Value(loggerKey{}).(Logger); ok {
+		return l
+	}
+	return NewSlogLogger(slog.Default())
+}
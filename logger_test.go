@@ -0,0 +1,24 @@
+package lazyservice
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestGetLoggerDefault(t *testing.T) {
+	if GetLogger(context.Background()) == nil {
+		t.Error("expected a default logger")
+	}
+}
+
+func TestGetLoggerFromContext(t *testing.T) {
+	base := NewSlogLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	l := base.With("service", "test")
+	ctx := context.WithValue(context.Background(), loggerKey{}, l)
+
+	if got := GetLogger(ctx); got != l {
+		t.Error("did not get back the logger bound to ctx")
+	}
+}
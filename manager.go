@@ -3,10 +3,14 @@ package lazyservice
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
-	"reflect"
+	"os/signal"
 	"runtime/trace"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/lmittmann/tint"
 	"golang.org/x/sync/errgroup"
@@ -14,10 +18,70 @@ import (
 	"golazy.dev/lazycontext"
 )
 
+// defaultStopTimeout is the deadline of the single context Run passes to
+// every service's Stop and to the after-stop hooks during shutdown,
+// unless SetStopTimeout is called.
+const defaultStopTimeout = 30 * time.Second
+
 type Manager interface {
 	lazycontext.AppContext
 	AddService(Service)
 	Run() error
+
+	// AddServiceWithPolicy adds s like AddService, but supervises it: if
+	// Run returns a non-context error accepted by policy.OnlyOn, the
+	// manager waits out policy.Backoff and invokes Run again, up to
+	// policy.MaxRestarts times, before letting the error propagate.
+	AddServiceWithPolicy(s Service, policy RestartPolicy)
+
+	// AddBeforeStart registers a hook that runs before any service is
+	// started. Hooks run sequentially in the order they were added; the
+	// first error aborts Run without starting any service.
+	AddBeforeStart(func(ctx context.Context) error)
+
+	// AddAfterStop registers a hook that runs after every service has
+	// been stopped, in the order the hooks were added.
+	AddAfterStop(func(ctx context.Context) error)
+
+	// SetStopTimeout sets the deadline of the one context shared by every
+	// service's Stop and the after-stop hooks during shutdown; it bounds
+	// the whole shutdown, not each service separately. The default is
+	// defaultStopTimeout.
+	SetStopTimeout(d time.Duration)
+
+	// OnReload registers a hook that is called whenever the app receives
+	// SIGHUP. Reload hooks run sequentially and do not cancel the app
+	// context or tear down any service.
+	OnReload(func(ctx context.Context) error)
+
+	// Services returns the services added so far, in the order they were
+	// added. It lets introspection services such as lazyservice/admin
+	// aggregate health and readiness across the registry.
+	Services() []Service
+
+	// Shutdown cancels the app's root context, the same way an incoming
+	// SIGINT/SIGTERM would, triggering Run's ordered shutdown. Calling it
+	// before Run has set up that context is remembered and applied as
+	// soon as Run catches up, rather than being silently dropped.
+	Shutdown()
+}
+
+// defaultSignals is the signal set Run traps when the manager was
+// created with New or NewWithContext.
+var defaultSignals = []os.Signal{os.Interrupt, syscall.SIGINT, syscall.SIGTERM}
+
+// managerBox holds a Manager for storage through lazycontext.Set/Get.
+// lazycontext keys values by the reflected type of T, which is nil for an
+// interface type, so Set[Manager] panics with "nil key"; boxing it in a
+// concrete struct keys it by managerBox instead.
+type managerBox struct{ m Manager }
+
+// ManagerFromContext returns the Manager whose Run produced ctx, or a
+// descendant context passed to one of its services, for introspection
+// services such as lazyservice/admin. It returns nil if ctx wasn't
+// derived from a running Manager.
+func ManagerFromContext(ctx context.Context) Manager {
+	return lazycontext.Get[managerBox](ctx).m
 }
 
 // New creates a new app setting the name and version of the app.
@@ -38,10 +102,24 @@ func NewWithContext(ctx context.Context) Manager {
 	}).init()
 }
 
+// NewWithSignals creates a new app that traps the given signals instead of
+// the default SIGINT/SIGTERM. Run wraps the app context with
+// signal.NotifyContext for sigs, and SIGHUP always triggers the reload
+// hooks registered through OnReload regardless of sigs.
+func NewWithSignals(sigs ...os.Signal) Manager {
+	return (&manager{
+		AppContext: lazycontext.New(),
+		sigs:       sigs,
+	}).init()
+}
+
 func (a *manager) init() *manager {
 	if a.done == nil {
 		a.done = make(chan struct{})
 	}
+	if a.stopTimeout == 0 {
+		a.stopTimeout = defaultStopTimeout
+	}
 
 	//	if a.name == "" {
 	//		a.name = filepath.Base(os.Args[0])
@@ -55,16 +133,68 @@ func (a *manager) init() *manager {
 
 type manager struct {
 	lazycontext.AppContext
-	logger   *slog.Logger
-	services []Service
-	done     chan struct{}
-	cancel   context.CancelFunc
+	logger Logger
+
+	cancelMu          sync.Mutex
+	cancel            context.CancelFunc
+	shutdownRequested bool
+
+	services    []Service
+	done        chan struct{}
+	beforeStart []func(ctx context.Context) error
+	afterStop   []func(ctx context.Context) error
+	stopTimeout time.Duration
+	sigs        []os.Signal
+	reloadHooks []func(ctx context.Context) error
+	policies    map[string]RestartPolicy
 }
 
 func (a *manager) AddService(s Service) {
 	a.services = append(a.services, s)
 }
 
+func (a *manager) AddServiceWithPolicy(s Service, policy RestartPolicy) {
+	a.AddService(s)
+	if a.policies == nil {
+		a.policies = make(map[string]RestartPolicy)
+	}
+	a.policies[s.Desc().Name()] = policy
+}
+
+func (a *manager) Services() []Service {
+	out := make([]Service, len(a.services))
+	copy(out, a.services)
+	return out
+}
+
+func (a *manager) AddBeforeStart(f func(ctx context.Context) error) {
+	a.beforeStart = append(a.beforeStart, f)
+}
+
+func (a *manager) AddAfterStop(f func(ctx context.Context) error) {
+	a.afterStop = append(a.afterStop, f)
+}
+
+func (a *manager) SetStopTimeout(d time.Duration) {
+	a.stopTimeout = d
+}
+
+func (a *manager) OnReload(f func(ctx context.Context) error) {
+	a.reloadHooks = append(a.reloadHooks, f)
+}
+
+func (a *manager) Shutdown() {
+	a.cancelMu.Lock()
+	cancel := a.cancel
+	if cancel == nil {
+		a.shutdownRequested = true
+	}
+	a.cancelMu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
 // Run runs the app and all its services.
 // If the app does not have a context, it creates a new one. This new context will be canceled when the app receives an interrupt signal.
 // The app sets up a default slog Logger unless one is set through AppSet.
@@ -72,18 +202,32 @@ func (a *manager) AddService(s Service) {
 // The logger will have the app name and version as attributes for the json output.
 // Each service will also have the service attribute set to the service name.
 // If any service returns, all the contexts will be canceled and the app will wait for all services to stop.
+// Services are started in dependency order (see DependsOn), after any before-start hooks have run
+// sequentially; a service only starts once every service it requires has become ready (see Readier).
+// Once every service has returned, services implementing Stopper are stopped in the reverse of
+// that dependency order, bounded by the manager's stop timeout, and finally the after-stop hooks run.
 // The application uses trace regions for the app and for each of the services.
 func (a *manager) Run() error {
-	//	if a.captureInt {
-	//a.ctx, a.cancel = signal.NotifyContext(a.ctx, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
-	//a.cancel()
-	//}
+	sigs := a.sigs
+	if len(sigs) == 0 {
+		sigs = defaultSignals
+	}
+	ctx, cancel := signal.NotifyContext(a.AppContext, sigs...)
+	a.cancelMu.Lock()
+	a.cancel = cancel
+	requested := a.shutdownRequested
+	a.cancelMu.Unlock()
+	defer cancel()
+	if requested {
+		cancel()
+	}
 
 	// Logger
-	a.logger = lazycontext.Get[*slog.Logger](a)
+	a.logger = lazycontext.Get[loggerBox](a).l
 	if a.logger == nil {
+		var slogger *slog.Logger
 		if term.IsTerminal(int(os.Stdout.Fd())) {
-			a.logger = slog.New(tint.NewHandler(os.Stdout,
+			slogger = slog.New(tint.NewHandler(os.Stdout,
 				&tint.Options{
 					AddSource: true,
 					Level:     slog.LevelDebug,
@@ -96,51 +240,269 @@ func (a *manager) Run() error {
 					},
 				}))
 		} else {
-			a.logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+			slogger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 				AddSource: true,
 				Level:     slog.LevelWarn,
 			}))
 
 		}
+		a.logger = NewSlogLogger(slogger)
 	}
 	go func() {
-		<-a.Done()
+		<-ctx.Done()
 		a.logger.Info("interrupt signal received")
 	}()
 
-	lazycontext.Set(a.AppContext, a.logger)
+	reloadCh := make(chan os.Signal, 1)
+	signal.Notify(reloadCh, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(reloadCh)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-reloadCh:
+				a.logger.Info("reload signal received")
+				for _, f := range a.reloadHooks {
+					if err := f(ctx); err != nil {
+						a.logger.Error("reload hook failed", "error", err)
+					}
+				}
+			}
+		}
+	}()
+
+	lazycontext.Set(a.AppContext, loggerBox{l: a.logger})
+	lazycontext.Set(a.AppContext, managerBox{m: a})
 
-	appRegion := trace.StartRegion(a, "lazyapp.Run")
+	appRegion := trace.StartRegion(ctx, "lazyapp.Run")
 	defer appRegion.End()
 
 	a.logger.Info("starting app")
 
-	grp, grpCtx := errgroup.WithContext(a)
+	for _, f := range a.beforeStart {
+		if err := f(ctx); err != nil {
+			a.logger.Error("before-start hook failed", "error", err)
+			return err
+		}
+	}
+
+	order, err := topoSort(a.services)
+	if err != nil {
+		a.logger.Error(err.Error())
+		return err
+	}
 
-	for _, s := range a.services {
+	byName := make(map[string]Service, len(order))
+	ready := make(map[string]*readyState, len(order))
+	for _, s := range order {
+		byName[s.Desc().Name()] = s
+		ready[s.Desc().Name()] = newReadyState()
+	}
+
+	grp, grpCtx := errgroup.WithContext(ctx)
+
+	// started collects the services that actually got past the
+	// dependency wait and Start, in launch order, so that an early exit
+	// below still stops only the services that were actually started,
+	// in the reverse of the order they were started.
+	started := make([]Service, 0, len(order))
+
+	for _, s := range order {
 		s := s // create a local variable and assign the value of s to it
+		name := s.Desc().Name()
+		rs := ready[name]
+
+		depErr := func() error {
+			if dep, ok := s.Desc().(DependsOn); ok {
+				for _, depName := range dep.Requires() {
+					var depReady <-chan struct{} = ready[depName].ch
+					if r, ok := byName[depName].(Readier); ok {
+						depReady = r.Ready()
+					}
+					select {
+					case <-depReady:
+					case <-grpCtx.Done():
+						return grpCtx.Err()
+					}
+				}
+			}
+			return nil
+		}()
+		if depErr != nil {
+			grp.Go(func() error { return depErr })
+			break
+		}
+
+		svcCtx := context.WithValue(grpCtx, readyStateKey{}, rs)
+
+		// firstRunReturned is closed once this service's first Run call
+		// returns, for services with no Starter: it lets such a service
+		// signal its own readiness with an explicit MarkReady(ctx) call
+		// partway through Run, while still falling back to marking it
+		// ready once Run returns if it never calls MarkReady at all.
+		// Services with a Starter are marked ready as soon as Start
+		// returns instead, so they need no such fallback.
+		var firstRunReturned chan struct{}
+
+		if starter, ok := s.(Starter); ok {
+			l := a.logger.With("service", name)
+			l.Info("starting service")
+			if err := starter.Start(svcCtx); err != nil {
+				l.Error(err.Error())
+				grp.Go(func() error { return err })
+				break
+			}
+			rs.markReady()
+		} else {
+			firstRunReturned = make(chan struct{})
+			go func() {
+				select {
+				case <-rs.ch:
+				case <-firstRunReturned:
+					rs.markReady()
+				}
+			}()
+		}
+		started = append(started, s)
+
+		policy, supervised := a.policies[name]
+
 		grp.Go(func() error {
-			l := a.logger.With("service", s.Desc().Name())
-			ctx := context.WithValue(grpCtx, reflect.TypeOf(a.logger), l)
+			l := a.logger.With("service", name)
+			ctx := context.WithValue(svcCtx, loggerKey{}, l)
 
-			srvReg := trace.StartRegion(ctx, "service:"+s.Desc().Name())
+			srvReg := trace.StartRegion(ctx, "service:"+name)
 			defer srvReg.End()
 
-			l.InfoContext(ctx, "starting service")
-			err := s.Run(ctx)
-			if errors.Is(err, context.Canceled) ||
-				errors.Is(err, context.DeadlineExceeded) {
-				l.InfoContext(ctx, "stopped")
-				return nil
-			}
-			if err != nil {
-				l.ErrorContext(ctx, err.Error())
+			attempt := 0
+			for {
+				l.Info("starting service")
+				start := time.Now()
+				err := s.Run(ctx)
+				if attempt == 0 && firstRunReturned != nil {
+					close(firstRunReturned)
+				}
+				if errors.Is(err, context.Canceled) ||
+					errors.Is(err, context.DeadlineExceeded) {
+					l.Info("stopped")
+					return nil
+				}
+				if err == nil {
+					l.Info("app gracefully stoped")
+					return nil
+				}
+				l.Error(err.Error())
+
+				if !supervised || (policy.OnlyOn != nil && !policy.OnlyOn(err)) {
+					return err
+				}
+				if policy.Backoff == nil {
+					l.Error("restart policy has no Backoff set, not restarting")
+					return err
+				}
+				if time.Since(start) > policy.Backoff.ResetAfter() {
+					attempt = 0
+				}
+				if attempt >= policy.MaxRestarts {
+					l.Error("service exceeded max restarts, giving up")
+					return err
+				}
+
+				delay := policy.Backoff.Delay(attempt)
+				attempt++
+				l.Warn("restarting service", "attempt", attempt, "delay", delay)
+				select {
+				case <-time.After(delay):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
 			}
-			l.InfoContext(ctx, "app gracefully stoped")
-			return err
 		})
 	}
-	return grp.Wait()
+	runErr := grp.Wait()
+
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), a.stopTimeout)
+	defer stopCancel()
+
+	for i := len(started) - 1; i >= 0; i-- {
+		s := started[i]
+		if stopper, ok := s.(Stopper); ok {
+			l := a.logger.With("service", s.Desc().Name())
+			l.Info("stopping service")
+			if err := stopper.Stop(stopCtx); err != nil {
+				l.Error(err.Error())
+			}
+		}
+	}
+
+	for _, f := range a.afterStop {
+		if err := f(stopCtx); err != nil {
+			a.logger.Error("after-stop hook failed", "error", err)
+		}
+	}
+
+	return runErr
+}
+
+// topoSort orders services so that every service implementing DependsOn
+// comes after the services it requires, returning an error if a required
+// name is unknown or if the dependencies form a cycle.
+func topoSort(services []Service) ([]Service, error) {
+	byName := make(map[string]Service, len(services))
+	for _, s := range services {
+		byName[s.Desc().Name()] = s
+	}
+
+	requires := func(s Service) []string {
+		if dep, ok := s.Desc().(DependsOn); ok {
+			return dep.Requires()
+		}
+		return nil
+	}
+
+	for _, s := range services {
+		for _, name := range requires(s) {
+			if _, ok := byName[name]; !ok {
+				return nil, fmt.Errorf("lazyservice: service %q requires unknown service %q", s.Desc().Name(), name)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(services))
+	order := make([]Service, 0, len(services))
+
+	var visit func(s Service) error
+	visit = func(s Service) error {
+		name := s.Desc().Name()
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("lazyservice: dependency cycle detected at service %q", name)
+		}
+		state[name] = visiting
+		for _, dep := range requires(s) {
+			if err := visit(byName[dep]); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		order = append(order, s)
+		return nil
+	}
+
+	for _, s := range services {
+		if err := visit(s); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
 }
 
 func getVersion() string {
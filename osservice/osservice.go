@@ -0,0 +1,133 @@
+// Package osservice lets a lazyservice.Manager run as a native OS
+// service (systemd unit, Windows SCM service, launchd job) using
+// github.com/kardianos/service, without changing how the binary behaves
+// when run directly in a terminal.
+package osservice
+
+import (
+	"time"
+
+	"github.com/kardianos/service"
+	"github.com/spf13/cobra"
+	"golazy.dev/lazyservice"
+)
+
+// Config describes the native OS service metadata used when installing
+// the binary.
+type Config struct {
+	Name        string
+	DisplayName string
+	Description string
+}
+
+// Run starts mgr under the OS service manager. When the binary is
+// launched directly (not through systemd/SCM/launchd), it simply runs
+// mgr in the foreground. It blocks until the service library calls Stop
+// (on a direct launch, when the process receives an interrupt or
+// termination signal), which shuts mgr down and waits up to 30 seconds
+// for mgr.Run to return; a mgr.Run that returns on its own does not end
+// Run.
+func Run(mgr lazyservice.Manager, cfg Config) error {
+	svc, err := service.New(&program{mgr: mgr}, serviceConfig(cfg))
+	if err != nil {
+		return err
+	}
+	return svc.Run()
+}
+
+// Command returns a cobra command that runs mgr in the foreground by
+// default, with install, uninstall, start, stop, and status subcommands
+// for managing the installed OS service.
+func Command(mgr lazyservice.Manager, cfg Config) *cobra.Command {
+	svcCfg := serviceConfig(cfg)
+
+	cmd := &cobra.Command{
+		Use:   cfg.Name,
+		Short: cfg.Description,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := service.New(&program{mgr: mgr}, svcCfg)
+			if err != nil {
+				return err
+			}
+			return svc.Run()
+		},
+	}
+
+	for _, action := range []string{"install", "uninstall", "start", "stop"} {
+		action := action
+		cmd.AddCommand(&cobra.Command{
+			Use:   action,
+			Short: action + " the " + cfg.Name + " service",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				svc, err := service.New(&program{mgr: mgr}, svcCfg)
+				if err != nil {
+					return err
+				}
+				return service.Control(svc, action)
+			},
+		})
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "report the status of the " + cfg.Name + " service",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := service.New(&program{mgr: mgr}, svcCfg)
+			if err != nil {
+				return err
+			}
+			status, err := svc.Status()
+			if err != nil {
+				return err
+			}
+			cmd.Println(statusString(status))
+			return nil
+		},
+	})
+
+	return cmd
+}
+
+func serviceConfig(cfg Config) *service.Config {
+	return &service.Config{
+		Name:        cfg.Name,
+		DisplayName: cfg.DisplayName,
+		Description: cfg.Description,
+	}
+}
+
+func statusString(status service.Status) string {
+	switch status {
+	case service.StatusRunning:
+		return "running"
+	case service.StatusStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
+// program adapts a lazyservice.Manager to the kardianos/service.Interface
+// the native service managers drive.
+type program struct {
+	mgr   lazyservice.Manager
+	errCh chan error
+}
+
+func (p *program) Start(s service.Service) error {
+	p.errCh = make(chan error, 1)
+	go func() {
+		p.errCh <- p.mgr.Run()
+	}()
+	return nil
+}
+
+func (p *program) Stop(s service.Service) error {
+	p.mgr.Shutdown()
+	select {
+	case err := <-p.errCh:
+		return err
+	case <-time.After(30 * time.Second):
+		return nil
+	}
+}
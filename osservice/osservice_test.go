@@ -0,0 +1,48 @@
+package osservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golazy.dev/lazyservice"
+)
+
+// blockingService runs until its context is canceled, so that program.Start
+// has something to run in the background for Stop to race against.
+type blockingService struct{}
+
+func (blockingService) Desc() lazyservice.ServiceDescription { return blockingDesc{} }
+func (blockingService) Run(ctx context.Context) error        { <-ctx.Done(); return ctx.Err() }
+
+type blockingDesc struct{}
+
+func (blockingDesc) Name() string { return "blocking" }
+
+// TestProgramStartStopRace exercises the exact call pattern
+// kardianos/service drives program through: Start launches mgr.Run in a
+// goroutine and returns immediately, and Stop may be called before that
+// goroutine has finished setting up, racing mgr.Shutdown against mgr.Run
+// with no synchronization between them. Run under -race, this guards
+// against the data race on Manager's internal cancel func; it also guards
+// against the shutdown signal being silently dropped by calling Stop as
+// soon as Start returns, before Run has necessarily reached the point
+// where it installs its cancel func.
+func TestProgramStartStopRace(t *testing.T) {
+	mgr := lazyservice.New()
+	mgr.AddService(blockingService{})
+
+	p := &program{mgr: mgr}
+	if err := p.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- p.Stop(nil) }()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Stop never returned")
+	}
+}
@@ -0,0 +1,186 @@
+// Package otel adds OpenTelemetry tracing and metrics to a
+// lazyservice.Manager: spans and metrics a production observability stack
+// can actually ingest, emitted alongside the runtime/trace regions Run
+// always emits.
+package otel
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"golazy.dev/lazycontext"
+	"golazy.dev/lazyservice"
+)
+
+// Config configures the TracerProvider and MeterProvider Setup installs.
+type Config struct {
+	// OTLPEndpoint is the collector address both the trace and metric
+	// exporters send to, e.g. "localhost:4317".
+	OTLPEndpoint string
+}
+
+// Instrumentation wraps services so their Run is traced and measured with
+// the tracer and instruments Setup created. The providers themselves are
+// shut down by the after-stop hook Setup registers, not by Instrumentation.
+type Instrumentation struct {
+	tracer   trace.Tracer
+	running  metric.Int64UpDownCounter
+	restarts metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// Setup installs a TracerProvider and MeterProvider exporting to
+// cfg.OTLPEndpoint, registers them as the otel globals and into mgr's app
+// context via lazycontext.Set, and arranges for both to be shut down by
+// an after-stop hook. Use the returned Instrumentation's Wrap method when
+// adding services you want traced and measured.
+func Setup(mgr lazyservice.Manager, cfg Config) (*Instrumentation, error) {
+	ctx := context.Background()
+
+	traceExp, err := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint),
+		otlptracegrpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(traceExp))
+	otel.SetTracerProvider(tp)
+
+	metricExp, err := otlpmetricgrpc.New(ctx,
+		otlpmetricgrpc.WithEndpoint(cfg.OTLPEndpoint),
+		otlpmetricgrpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExp)))
+	otel.SetMeterProvider(mp)
+
+	lazycontext.Set(mgr, tp)
+	lazycontext.Set(mgr, mp)
+
+	meter := mp.Meter("golazy.dev/lazyservice")
+	running, err := meter.Int64UpDownCounter("lazyservice.service.running")
+	if err != nil {
+		return nil, err
+	}
+	restarts, err := meter.Int64Counter("lazyservice.service.restarts")
+	if err != nil {
+		return nil, err
+	}
+	duration, err := meter.Float64Histogram("lazyservice.service.duration")
+	if err != nil {
+		return nil, err
+	}
+
+	mgr.AddAfterStop(func(ctx context.Context) error {
+		if err := tp.Shutdown(ctx); err != nil {
+			return err
+		}
+		return mp.Shutdown(ctx)
+	})
+
+	return &Instrumentation{
+		tracer:   tp.Tracer("golazy.dev/lazyservice"),
+		running:  running,
+		restarts: restarts,
+		duration: duration,
+	}, nil
+}
+
+// Wrap instruments svc so its Run executes inside a span named
+// "service.<name>" with status and error recorded, while
+// lazyservice.service.running, .restarts, and .duration are reported for
+// it. The returned Service implements Starter, Stopper, Readier, and
+// HealthChecker if and only if svc does, so AddService(instr.Wrap(svc))
+// behaves the same as AddService(svc) for lifecycle purposes: wrapping a
+// service that signals readiness with lazyservice.MarkReady, say, doesn't
+// make the manager treat it as ready as soon as a (nonexistent) Start
+// returns.
+func (i *Instrumentation) Wrap(svc lazyservice.Service) lazyservice.Service {
+	var wrapped lazyservice.Service = &instrumented{Service: svc, instr: i}
+
+	if st, ok := svc.(lazyservice.Starter); ok {
+		wrapped = withStart{Service: wrapped, start: st.Start}
+	}
+	if sp, ok := svc.(lazyservice.Stopper); ok {
+		wrapped = withStop{Service: wrapped, stop: sp.Stop}
+	}
+	if r, ok := svc.(lazyservice.Readier); ok {
+		wrapped = withReady{Service: wrapped, ready: r.Ready}
+	}
+	if hc, ok := svc.(lazyservice.HealthChecker); ok {
+		wrapped = withHealthCheck{Service: wrapped, healthCheck: hc.HealthCheck}
+	}
+	return wrapped
+}
+
+type instrumented struct {
+	lazyservice.Service
+	instr *Instrumentation
+}
+
+func (s *instrumented) Run(ctx context.Context) error {
+	name := s.Service.Desc().Name()
+	ctx, span := s.instr.tracer.Start(ctx, "service."+name)
+	defer span.End()
+
+	attrs := metric.WithAttributes(attribute.String("service", name))
+	s.instr.running.Add(ctx, 1, attrs)
+	defer s.instr.running.Add(ctx, -1, attrs)
+
+	start := time.Now()
+	err := s.Service.Run(ctx)
+	s.instr.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		s.instr.restarts.Add(ctx, 1, attrs)
+		return err
+	}
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// withStart, withStop, withReady, and withHealthCheck each add exactly one
+// optional lifecycle method on top of an already-wrapped Service, so Wrap
+// can compose only the ones svc actually implements instead of a
+// combinatorial type per subset.
+
+type withStart struct {
+	lazyservice.Service
+	start func(ctx context.Context) error
+}
+
+func (w withStart) Start(ctx context.Context) error { return w.start(ctx) }
+
+type withStop struct {
+	lazyservice.Service
+	stop func(ctx context.Context) error
+}
+
+func (w withStop) Stop(ctx context.Context) error { return w.stop(ctx) }
+
+type withReady struct {
+	lazyservice.Service
+	ready func() <-chan struct{}
+}
+
+func (w withReady) Ready() <-chan struct{} { return w.ready() }
+
+type withHealthCheck struct {
+	lazyservice.Service
+	healthCheck func(ctx context.Context) error
+}
+
+func (w withHealthCheck) HealthCheck(ctx context.Context) error { return w.healthCheck(ctx) }
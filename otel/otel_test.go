@@ -0,0 +1,55 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"golazy.dev/lazyservice"
+)
+
+// plainService implements only lazyservice.Service, for exercising that
+// Wrap doesn't grant capabilities a wrapped service doesn't have.
+type plainService struct{}
+
+func (plainService) Desc() lazyservice.ServiceDescription { return plainDesc{} }
+func (plainService) Run(ctx context.Context) error        { return nil }
+
+type plainDesc struct{}
+
+func (plainDesc) Name() string { return "plain" }
+
+// starterService additionally implements lazyservice.Starter.
+type starterService struct{ plainService }
+
+func (starterService) Start(ctx context.Context) error { return nil }
+
+// TestWrapComposesOnlyImplementedCapabilities guards against the bug
+// 76ce780 fixed, where every wrapped service satisfied Starter regardless
+// of what it wrapped: Wrap's returned Service must implement Starter,
+// Stopper, Readier, and HealthChecker if and only if the wrapped service
+// does.
+func TestWrapComposesOnlyImplementedCapabilities(t *testing.T) {
+	instr := &Instrumentation{}
+
+	plain := instr.Wrap(plainService{})
+	if _, ok := plain.(lazyservice.Starter); ok {
+		t.Error("wrapping a plain service should not produce a Starter")
+	}
+	if _, ok := plain.(lazyservice.Stopper); ok {
+		t.Error("wrapping a plain service should not produce a Stopper")
+	}
+	if _, ok := plain.(lazyservice.Readier); ok {
+		t.Error("wrapping a plain service should not produce a Readier")
+	}
+	if _, ok := plain.(lazyservice.HealthChecker); ok {
+		t.Error("wrapping a plain service should not produce a HealthChecker")
+	}
+
+	withStarter := instr.Wrap(starterService{})
+	if _, ok := withStarter.(lazyservice.Starter); !ok {
+		t.Error("wrapping a Starter should produce a Starter")
+	}
+	if _, ok := withStarter.(lazyservice.Stopper); ok {
+		t.Error("wrapping a service with no Stopper should not produce a Stopper")
+	}
+}
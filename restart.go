@@ -0,0 +1,53 @@
+package lazyservice
+
+import (
+	"math/rand"
+	"time"
+)
+
+// RestartPolicy supervises a service added through AddServiceWithPolicy:
+// when its Run returns a non-context error that OnlyOn accepts, the
+// manager waits for Backoff's delay and invokes Run again, up to
+// MaxRestarts times, before giving up and letting the error propagate.
+type RestartPolicy struct {
+	MaxRestarts int
+	// Backoff is required: if nil, the manager logs an error and lets the
+	// triggering error propagate instead of restarting.
+	Backoff Backoff
+	// OnlyOn reports whether err should trigger a restart. If nil, every
+	// non-context error does.
+	OnlyOn func(err error) bool
+}
+
+// Backoff computes the delay before a supervised service's next restart
+// attempt, and how long it must stay up before the attempt count resets.
+type Backoff interface {
+	Delay(attempt int) time.Duration
+	ResetAfter() time.Duration
+}
+
+// ExponentialBackoff doubles the delay on each attempt, up to Max, and
+// randomizes it by +/- Jitter/2 to avoid synchronized restarts.
+type ExponentialBackoff struct {
+	Base   time.Duration
+	Max    time.Duration
+	Jitter float64
+}
+
+func (b ExponentialBackoff) Delay(attempt int) time.Duration {
+	d := b.Base * time.Duration(uint64(1)<<uint(attempt))
+	if d <= 0 || d > b.Max {
+		d = b.Max
+	}
+	if b.Jitter > 0 {
+		factor := 1 + rand.Float64()*b.Jitter - b.Jitter/2
+		d = time.Duration(float64(d) * factor)
+	}
+	return d
+}
+
+// ResetAfter reports that a service that stayed up for more than
+// 10*Base had its failure forgiven, restarting attempt counting from 0.
+func (b ExponentialBackoff) ResetAfter() time.Duration {
+	return 10 * b.Base
+}
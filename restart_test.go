@@ -0,0 +1,28 @@
+package lazyservice
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExponentialBackoffDelay(t *testing.T) {
+	b := ExponentialBackoff{Base: 100 * time.Millisecond, Max: time.Second}
+
+	if d := b.Delay(0); d != 100*time.Millisecond {
+		t.Error("delay for attempt 0 is:", d)
+	}
+	if d := b.Delay(1); d != 200*time.Millisecond {
+		t.Error("delay for attempt 1 is:", d)
+	}
+	if d := b.Delay(10); d != time.Second {
+		t.Error("delay should be capped at Max:", d)
+	}
+}
+
+func TestExponentialBackoffResetAfter(t *testing.T) {
+	b := ExponentialBackoff{Base: 100 * time.Millisecond, Max: time.Second}
+
+	if d := b.ResetAfter(); d != time.Second {
+		t.Error("reset-after is:", d)
+	}
+}
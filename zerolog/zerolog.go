@@ -0,0 +1,50 @@
+// Package zerolog adapts github.com/rs/zerolog to lazyservice.Logger, for
+// apps that already standardize on zerolog's structured, allocation-free
+// logging.
+package zerolog
+
+import (
+	"github.com/rs/zerolog"
+	"golazy.dev/lazyservice"
+)
+
+// New adapts a zerolog.Logger to lazyservice.Logger. Set it with
+// lazyservice.SetLogger before calling Manager.Run to use it as the app's
+// logger.
+func New(l zerolog.Logger) lazyservice.Logger {
+	return adapter{l: l}
+}
+
+type adapter struct {
+	l zerolog.Logger
+}
+
+func (a adapter) Debug(msg string, kv ...any) { fields(a.l.Debug(), kv...).Msg(msg) }
+func (a adapter) Info(msg string, kv ...any)  { fields(a.l.Info(), kv...).Msg(msg) }
+func (a adapter) Warn(msg string, kv ...any)  { fields(a.l.Warn(), kv...).Msg(msg) }
+func (a adapter) Error(msg string, kv ...any) { fields(a.l.Error(), kv...).Msg(msg) }
+
+func (a adapter) With(kv ...any) lazyservice.Logger {
+	ctx := a.l.With()
+	for i := 0; i+1 < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			continue
+		}
+		ctx = ctx.Interface(key, kv[i+1])
+	}
+	return adapter{l: ctx.Logger()}
+}
+
+// fields applies alternating key/value pairs to a zerolog event, the same
+// convention Logger's callers use for slog and hclog.
+func fields(e *zerolog.Event, kv ...any) *zerolog.Event {
+	for i := 0; i+1 < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			continue
+		}
+		e = e.Interface(key, kv[i+1])
+	}
+	return e
+}
@@ -0,0 +1,62 @@
+package zerolog
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	zl "github.com/rs/zerolog"
+)
+
+func TestAdapterLevelsAndFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(zl.New(&buf))
+	l.Info("listening", "port", 8080)
+
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatal(err)
+	}
+	if line["message"] != "listening" {
+		t.Errorf("message is: %v", line["message"])
+	}
+	if line["level"] != "info" {
+		t.Errorf("level is: %v", line["level"])
+	}
+	if line["port"] != float64(8080) {
+		t.Errorf("port is: %v", line["port"])
+	}
+}
+
+func TestAdapterWithAddsFieldsToSubsequentCalls(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(zl.New(&buf))
+	l = l.With("service", "http")
+	l.Warn("slow request")
+
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatal(err)
+	}
+	if line["service"] != "http" {
+		t.Errorf("service is: %v", line["service"])
+	}
+	if line["message"] != "slow request" {
+		t.Errorf("message is: %v", line["message"])
+	}
+}
+
+func TestAdapterWithIgnoresNonStringKeys(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(zl.New(&buf))
+	l = l.With(42, "ignored")
+	l.Info("ok")
+
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := line["42"]; ok {
+		t.Errorf("non-string key should have been skipped, got: %v", line)
+	}
+}